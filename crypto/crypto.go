@@ -7,6 +7,7 @@ package crypto
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 )
@@ -23,3 +24,24 @@ func HashToken(token string) string {
 	h := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(h[:])
 }
+
+// RotateRefreshToken generates a fresh opaque refresh token to replace the
+// one whose hash is oldHash, returning both the new plaintext token (to
+// hand back to the client) and its SHA-256 hash (to persist in place of
+// oldHash). oldHash is not otherwise used here; callers typically pass it
+// through for audit logging alongside the rotation.
+func RotateRefreshToken(oldHash string) (newToken, newHash string, err error) {
+	newToken, err = GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	return newToken, HashToken(newToken), nil
+}
+
+// ConstantTimeCompareHash reports whether two token hashes are equal,
+// without leaking timing information about where they first differ. Use
+// this instead of == when comparing a presented token's hash against one
+// stored at rest.
+func ConstantTimeCompareHash(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2022-2025. Daydev, Inc. All Rights Reserved
+ */
+
+package crypto
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc4226Secret is the 20-byte ASCII secret used throughout RFC 4226 and
+// RFC 6238's test vectors, base32-encoded for use with this package.
+const rfc4226Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHOTPCodeRFC4226Vectors(t *testing.T) {
+	// RFC 4226 Appendix D, counters 0-9.
+	want := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+	for counter, expected := range want {
+		got, err := HOTPCode(rfc4226Secret, uint64(counter), TOTPOptions{})
+		if err != nil {
+			t.Fatalf("HOTPCode(counter=%d): %v", counter, err)
+		}
+		if got != expected {
+			t.Errorf("HOTPCode(counter=%d) = %q, want %q", counter, got, expected)
+		}
+	}
+}
+
+func TestTOTPCodeRFC6238Vector(t *testing.T) {
+	// RFC 6238 Appendix B, T=59s, SHA1, 6 digits -> counter 1.
+	got, err := TOTPCode(rfc4226Secret, time.Unix(59, 0), TOTPOptions{})
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+	if got != "287082" {
+		t.Errorf("TOTPCode(t=59) = %q, want %q", got, "287082")
+	}
+}
+
+func TestVerifyTOTPMatchesNonDefaultOptions(t *testing.T) {
+	opts := TOTPOptions{Digits: 8, Period: 60 * time.Second, Algorithm: TOTPAlgorithmSHA256}
+	secret, err := GenerateTOTPSecret(20)
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	code, err := TOTPCode(secret, now, opts)
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+
+	if !VerifyTOTP(secret, code, now, 0, opts) {
+		t.Errorf("VerifyTOTP rejected a code generated with the same options")
+	}
+}
+
+func TestVerifyTOTPRejectsMismatchedOptions(t *testing.T) {
+	opts := TOTPOptions{Digits: 8, Period: 60 * time.Second, Algorithm: TOTPAlgorithmSHA256}
+	secret, err := GenerateTOTPSecret(20)
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	code, err := TOTPCode(secret, now, opts)
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+
+	if VerifyTOTP(secret, code, now, 0, TOTPOptions{}) {
+		t.Errorf("VerifyTOTP accepted a code against the wrong options")
+	}
+}
+
+func TestVerifyTOTPSkewWindow(t *testing.T) {
+	opts := TOTPOptions{}
+	now := time.Unix(1_700_000_000, 0)
+	code, err := TOTPCode(rfc4226Secret, now.Add(-opts.period()), opts)
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+
+	if VerifyTOTP(rfc4226Secret, code, now, 0, opts) {
+		t.Errorf("VerifyTOTP accepted a stale code with zero skew")
+	}
+	if !VerifyTOTP(rfc4226Secret, code, now, 1, opts) {
+		t.Errorf("VerifyTOTP rejected a code within the skew window")
+	}
+}
+
+func TestOTPAuthURLFormat(t *testing.T) {
+	url := OTPAuthURL("Example", "alice@example.com", rfc4226Secret, TOTPOptions{Digits: 8, Algorithm: TOTPAlgorithmSHA512})
+	if !strings.HasPrefix(url, "otpauth://totp/Example:alice@example.com?") {
+		t.Fatalf("unexpected OTPAuthURL prefix: %s", url)
+	}
+	for _, want := range []string{"secret=" + rfc4226Secret, "issuer=Example", "algorithm=SHA512", "digits=8"} {
+		if !strings.Contains(url, want) {
+			t.Errorf("OTPAuthURL %q missing %q", url, want)
+		}
+	}
+}
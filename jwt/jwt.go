@@ -13,3 +13,13 @@ func GenerateToken(secret []byte, claims jwt.Claims) (string, error) {
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString(secret)
 }
+
+// GenerateTokenWithMethod signs claims with method, accepting whatever key
+// type that method expects: []byte for the HMAC family, *rsa.PrivateKey for
+// RS256/RS384/RS512, *ecdsa.PrivateKey for ES256/ES384/ES512, and
+// ed25519.PrivateKey for EdDSA. Use this instead of GenerateToken when the
+// token needs an asymmetric algorithm.
+func GenerateTokenWithMethod(method jwt.SigningMethod, key interface{}, claims jwt.Claims) (string, error) {
+	t := jwt.NewWithClaims(method, claims)
+	return t.SignedString(key)
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2022-2025. Daydev, Inc. All Rights Reserved
+ */
+
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySet resolves a token's "kid" header to a verification key. It can be
+// backed by a fixed set of PEM-encoded keys, or by a JWKS endpoint that is
+// fetched once and then refreshed on a timer for as long as the KeySet is
+// in use.
+type KeySet struct {
+	mu   sync.RWMutex
+	keys map[string]interface{}
+
+	jwksURL string
+	client  *http.Client
+	stop    chan struct{}
+}
+
+// NewKeySetFromPEM builds a KeySet from a fixed map of kid to PEM-encoded
+// key (RSA, EC, or Ed25519, public or private - a private key's public
+// half is extracted automatically, since verification only ever needs
+// that). It never refreshes; use NewKeySetFromJWKS for keys that rotate on
+// their own schedule.
+func NewKeySetFromPEM(pemKeys map[string]string) (*KeySet, error) {
+	keys := make(map[string]interface{}, len(pemKeys))
+	for kid, pemStr := range pemKeys {
+		key, err := parsePEMKey([]byte(pemStr))
+		if err != nil {
+			return nil, fmt.Errorf("jwt: key %q: %w", kid, err)
+		}
+		keys[kid] = key
+	}
+	return &KeySet{keys: keys}, nil
+}
+
+// NewKeySetFromJWKS fetches the JWKS document at jwksURL, decodes its RSA,
+// EC, and OKP (Ed25519) keys, and refreshes it every refreshEvery in the
+// background until Close is called.
+func NewKeySetFromJWKS(jwksURL string, refreshEvery time.Duration) (*KeySet, error) {
+	ks := &KeySet{
+		keys:    map[string]interface{}{},
+		jwksURL: jwksURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		stop:    make(chan struct{}),
+	}
+	if err := ks.refresh(); err != nil {
+		return nil, err
+	}
+	go ks.refreshLoop(refreshEvery)
+	return ks, nil
+}
+
+// Close stops the background refresh goroutine started by
+// NewKeySetFromJWKS. It is a no-op for PEM-backed key sets.
+func (ks *KeySet) Close() {
+	if ks.stop != nil {
+		close(ks.stop)
+	}
+}
+
+func (ks *KeySet) refreshLoop(every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = ks.refresh() // keep serving the last good keys on a transient fetch error
+		case <-ks.stop:
+			return
+		}
+	}
+}
+
+// KeyFunc returns a jwt.Keyfunc that resolves a token's "kid" header
+// against ks, suitable for passing straight to ParseAndVerify or
+// Verifier.Verify.
+func (ks *KeySet) KeyFunc() jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("jwt: token has no kid header")
+		}
+		ks.mu.RLock()
+		key, ok := ks.keys[kid]
+		ks.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("jwt: no key for kid %q", kid)
+		}
+		return key, nil
+	}
+}
+
+type jwksDocument struct {
+	Keys []jwkKey `json:"keys"`
+}
+
+type jwkKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (ks *KeySet) refresh() error {
+	resp, err := ks.client.Get(ks.jwksURL)
+	if err != nil {
+		return fmt.Errorf("jwt: fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("jwt: decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(doc.Keys))
+	for _, k := range doc.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return fmt.Errorf("jwt: JWKS key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+func (k jwkKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		return k.rsaPublicKey()
+	case "EC":
+		return k.ecdsaPublicKey()
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func (k jwkKey) rsaPublicKey() (interface{}, error) {
+	nb, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	eb, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	e := 0
+	for _, b := range eb {
+		e = e<<8 | int(b)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nb), E: e}, nil
+}
+
+func (k jwkKey) ecdsaPublicKey() (interface{}, error) {
+	curve, err := ecdsaCurve(k.Crv)
+	if err != nil {
+		return nil, err
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+func ecdsaCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+}
+
+// parsePEMKey tries each key type golang-jwt supports, public or private,
+// and returns the first one that parses successfully. KeySet only ever
+// verifies signatures, so a private key's public half is returned instead
+// of the private key itself - golang-jwt's Verify methods reject anything
+// else (e.g. RSA.Verify rejects an *rsa.PrivateKey outright).
+func parsePEMKey(pemBytes []byte) (interface{}, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes); err == nil {
+		return &key.PublicKey, nil
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPrivateKeyFromPEM(pemBytes); err == nil {
+		return &key.PublicKey, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes); err == nil {
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: unexpected Ed25519 private key type %T", key)
+		}
+		return edKey.Public(), nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("jwt: unrecognized PEM key format")
+}
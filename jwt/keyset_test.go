@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2022-2025. Daydev, Inc. All Rights Reserved
+ */
+
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func generateTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	return key
+}
+
+func rsaPrivatePEM(key *rsa.PrivateKey) string {
+	der := x509.MarshalPKCS1PrivateKey(key)
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}
+	return string(pem.EncodeToMemory(block))
+}
+
+func TestKeySetFromPEMResolvesByKid(t *testing.T) {
+	key := generateTestRSAKey(t)
+
+	ks, err := NewKeySetFromPEM(map[string]string{"kid-1": rsaPrivatePEM(key)})
+	if err != nil {
+		t.Fatalf("NewKeySetFromPEM: %v", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{})
+	token.Header["kid"] = "kid-1"
+	tokenStr, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	v := NewVerifier("RS256")
+	var claims jwt.RegisteredClaims
+	if err := v.Verify(tokenStr, &claims, ks.KeyFunc()); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestKeySetFromPEMUnknownKidFails(t *testing.T) {
+	key := generateTestRSAKey(t)
+	ks, err := NewKeySetFromPEM(map[string]string{"kid-1": rsaPrivatePEM(key)})
+	if err != nil {
+		t.Fatalf("NewKeySetFromPEM: %v", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{})
+	token.Header["kid"] = "missing"
+	tokenStr, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	v := NewVerifier("RS256")
+	var claims jwt.RegisteredClaims
+	if err := v.Verify(tokenStr, &claims, ks.KeyFunc()); err == nil {
+		t.Fatal("Verify succeeded against a kid the key set doesn't have")
+	}
+}
+
+// encodeRSAExponent renders an RSA public exponent as the big-endian bytes
+// a JWK's "e" field expects, trimming the leading zero bytes e's int type
+// would otherwise carry.
+func encodeRSAExponent(e int) []byte {
+	b := []byte{byte(e >> 16), byte(e >> 8), byte(e)}
+	i := 0
+	for i < len(b)-1 && b[i] == 0 {
+		i++
+	}
+	return b[i:]
+}
+
+// jwksServer serves a single RSA JWK under kid for as long as it's running.
+func jwksServer(t *testing.T, kid string, pub *rsa.PublicKey) *httptest.Server {
+	t.Helper()
+	n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(encodeRSAExponent(pub.E))
+
+	doc := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": kid, "n": n, "e": e},
+		},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+}
+
+func TestKeySetFromJWKSResolvesByKid(t *testing.T) {
+	key := generateTestRSAKey(t)
+	srv := jwksServer(t, "kid-jwks", &key.PublicKey)
+	defer srv.Close()
+
+	ks, err := NewKeySetFromJWKS(srv.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySetFromJWKS: %v", err)
+	}
+	defer ks.Close()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{})
+	token.Header["kid"] = "kid-jwks"
+	tokenStr, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	v := NewVerifier("RS256")
+	var claims jwt.RegisteredClaims
+	if err := v.Verify(tokenStr, &claims, ks.KeyFunc()); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
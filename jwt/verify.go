@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2022-2025. Daydev, Inc. All Rights Reserved
+ */
+
+package jwt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoAllowedAlgorithms is returned when a Verifier has an empty allow-list
+// after "none" is stripped out, since that would otherwise silently reject
+// every token.
+var ErrNoAllowedAlgorithms = errors.New("jwt: verifier has no allowed algorithms")
+
+// Verifier validates tokens against an explicit allow-list of signing
+// algorithms. This closes off "alg=none" and algorithm-confusion attacks,
+// where a token signed with one algorithm (e.g. HS256, using an RSA public
+// key as the HMAC secret) is accepted as if it had been signed with
+// another.
+type Verifier struct {
+	// AllowedAlgorithms lists the JWT "alg" values this Verifier accepts,
+	// e.g. []string{"RS256", "ES256"}. "none" is always rejected even if
+	// present here.
+	AllowedAlgorithms []string
+
+	// Issuer, if set, is required to match the token's "iss" claim.
+	Issuer string
+
+	// Audience, if set, is required to match one of the token's "aud"
+	// claims.
+	Audience string
+}
+
+// NewVerifier builds a Verifier that only accepts the given algorithms.
+func NewVerifier(allowedAlgorithms ...string) *Verifier {
+	return &Verifier{AllowedAlgorithms: allowedAlgorithms}
+}
+
+// Verify parses tokenStr into claims, using keyFunc to resolve the
+// verification key, and enforces v's algorithm allow-list plus any
+// configured issuer/audience. It returns a non-nil error for every failure
+// mode: malformed tokens, disallowed/none algorithms, bad signatures, and
+// expired/not-yet-valid/issuer/audience mismatches.
+func (v *Verifier) Verify(tokenStr string, claims jwt.Claims, keyFunc jwt.Keyfunc) error {
+	allowed := v.allowedAlgorithms()
+	if len(allowed) == 0 {
+		return ErrNoAllowedAlgorithms
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(allowed)}
+	if v.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.Issuer))
+	}
+	if v.Audience != "" {
+		opts = append(opts, jwt.WithAudience(v.Audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc, opts...)
+	if err != nil {
+		return fmt.Errorf("jwt: verify: %w", err)
+	}
+	if !token.Valid {
+		return fmt.Errorf("jwt: verify: %w", jwt.ErrTokenSignatureInvalid)
+	}
+	return nil
+}
+
+// allowedAlgorithms returns v.AllowedAlgorithms with "none" stripped, so a
+// caller that misconfigures it can't accidentally permit unsigned tokens.
+func (v *Verifier) allowedAlgorithms() []string {
+	out := make([]string, 0, len(v.AllowedAlgorithms))
+	for _, alg := range v.AllowedAlgorithms {
+		if alg == "none" {
+			continue
+		}
+		out = append(out, alg)
+	}
+	return out
+}
+
+// ParseAndVerify is a convenience wrapper around Verifier for one-off
+// verification against every algorithm this package signs with (excluding
+// "none"). Services that should only ever accept one or two algorithms
+// should construct a Verifier directly instead, so a compromised signer
+// using a weaker algorithm can't be used to forge tokens.
+func ParseAndVerify(tokenStr string, claims jwt.Claims, keyFunc jwt.Keyfunc) error {
+	v := NewVerifier(
+		"HS256", "HS384", "HS512",
+		"RS256", "RS384", "RS512",
+		"ES256", "ES384", "ES512",
+		"EdDSA",
+	)
+	return v.Verify(tokenStr, claims, keyFunc)
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2022-2025. Daydev, Inc. All Rights Reserved
+ */
+
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifierAcceptsValidToken(t *testing.T) {
+	secret := []byte("test-secret")
+	claims := jwt.RegisteredClaims{
+		Issuer:    "daydev",
+		Audience:  jwt.ClaimStrings{"api"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	tokenStr, err := GenerateToken(secret, claims)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	v := &Verifier{AllowedAlgorithms: []string{"HS256"}, Issuer: "daydev", Audience: "api"}
+	var got jwt.RegisteredClaims
+	if err := v.Verify(tokenStr, &got, func(*jwt.Token) (interface{}, error) { return secret, nil }); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestVerifierRejectsNoneAlgorithm(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{})
+	tokenStr, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing none token: %v", err)
+	}
+
+	// A misconfigured allow-list that still lists "none" must not let it through.
+	v := &Verifier{AllowedAlgorithms: []string{"none", "HS256"}}
+	var claims jwt.RegisteredClaims
+	err = v.Verify(tokenStr, &claims, func(*jwt.Token) (interface{}, error) {
+		return jwt.UnsafeAllowNoneSignatureType, nil
+	})
+	if err == nil {
+		t.Fatal("Verify accepted an alg=none token")
+	}
+}
+
+func TestVerifierRejectsDisallowedAlgorithm(t *testing.T) {
+	secret := []byte("test-secret")
+	tokenStr, err := GenerateToken(secret, jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	// Token is signed HS256, but this Verifier only trusts RS256.
+	v := &Verifier{AllowedAlgorithms: []string{"RS256"}}
+	var claims jwt.RegisteredClaims
+	err = v.Verify(tokenStr, &claims, func(*jwt.Token) (interface{}, error) { return secret, nil })
+	if err == nil {
+		t.Fatal("Verify accepted a token signed with a disallowed algorithm")
+	}
+}
+
+func TestVerifierRejectsIssuerMismatch(t *testing.T) {
+	secret := []byte("test-secret")
+	tokenStr, err := GenerateToken(secret, jwt.RegisteredClaims{Issuer: "someone-else"})
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	v := &Verifier{AllowedAlgorithms: []string{"HS256"}, Issuer: "daydev"}
+	var claims jwt.RegisteredClaims
+	err = v.Verify(tokenStr, &claims, func(*jwt.Token) (interface{}, error) { return secret, nil })
+	if err == nil {
+		t.Fatal("Verify accepted a token from an unexpected issuer")
+	}
+}
+
+func TestVerifierEmptyAllowListIsRejected(t *testing.T) {
+	v := &Verifier{AllowedAlgorithms: []string{"none"}}
+	var claims jwt.RegisteredClaims
+	err := v.Verify("irrelevant", &claims, func(*jwt.Token) (interface{}, error) { return nil, nil })
+	if err != ErrNoAllowedAlgorithms {
+		t.Fatalf("Verify error = %v, want %v", err, ErrNoAllowedAlgorithms)
+	}
+}
+
+func TestParseAndVerifyRoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+	tokenStr, err := GenerateToken(secret, jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	var claims jwt.RegisteredClaims
+	if err := ParseAndVerify(tokenStr, &claims, func(*jwt.Token) (interface{}, error) { return secret, nil }); err != nil {
+		t.Fatalf("ParseAndVerify: %v", err)
+	}
+}
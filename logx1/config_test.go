@@ -0,0 +1,40 @@
+package logx1
+
+import (
+	"testing"
+
+	"github.com/daydev-org/zap/zapcore"
+)
+
+func TestNewWithConfigRequiresASink(t *testing.T) {
+	if _, err := NewWithConfig(BuildConfig{}); err == nil {
+		t.Fatal("NewWithConfig accepted a config with no sinks")
+	}
+}
+
+func TestBuildSinkCoreRejectsUnknownEncoding(t *testing.T) {
+	if _, _, err := buildSinkCore(SinkConfig{Path: "stdout", Encoding: "xml"}); err == nil {
+		t.Fatal("buildSinkCore accepted an unknown encoding")
+	}
+}
+
+func TestRotationSinkURLEncodesOptions(t *testing.T) {
+	got := rotationSinkURL("/var/log/app.log", Rotation{MaxSizeMB: 100, MaxBackups: 3, MaxAgeDays: 7, Compress: true})
+	want := "lumberjack:///var/log/app.log?compress=true&maxage=7&maxbackups=3&maxsize=100"
+	if got != want {
+		t.Errorf("rotationSinkURL() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildSinkCoreAcceptsJSONAndConsoleEncodings(t *testing.T) {
+	for _, encoding := range []string{"", "json", "console"} {
+		core, closeFn, err := buildSinkCore(SinkConfig{Path: "stdout", Encoding: encoding, Level: zapcore.InfoLevel})
+		if err != nil {
+			t.Fatalf("buildSinkCore(encoding=%q): %v", encoding, err)
+		}
+		closeFn()
+		if core == nil {
+			t.Errorf("buildSinkCore(encoding=%q) returned a nil core", encoding)
+		}
+	}
+}
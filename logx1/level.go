@@ -0,0 +1,278 @@
+package logx1
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/daydev-org/zap"
+	"github.com/daydev-org/zap/zapcore"
+)
+
+// TraceLevel sits one step below zapcore.DebugLevel. It is meant for the
+// kind of per-call, high-volume logging you only ever want turned on for a
+// single package or file while chasing down a specific bug, never for a
+// whole service.
+const TraceLevel = zapcore.Level(zapcore.DebugLevel - 1)
+
+// TraceLevelEncoder renders TraceLevel as "TRACE" and falls back to
+// zapcore.CapitalLevelEncoder for every other level, so an encoder config
+// built with NewDevelopmentEncoderConfig keeps its level output unchanged
+// once Trace logging is in use. With NewProductionEncoderConfig, whose
+// default level encoding is lowercase, the other levels become uppercase.
+func TraceLevelEncoder(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if lvl == TraceLevel {
+		enc.AppendString("TRACE")
+		return
+	}
+	zapcore.CapitalLevelEncoder(lvl, enc)
+}
+
+// TraceFilterRule allow/deny-lists package or file path prefixes for Trace
+// logging. It is checked against the call site of Trace/Tracef (via
+// runtime.Caller), independently of the per-logger level table, so Trace
+// can be scoped to "this one file" even when its logger's configured level
+// is already TraceLevel or lower.
+//
+// A call site is eligible when its file matches an Allow prefix (or Allow
+// is empty, meaning "everywhere") and does not match any Deny prefix. Deny
+// always wins over Allow.
+type TraceFilterRule struct {
+	Allow []string
+	Deny  []string
+}
+
+func (r *TraceFilterRule) permits(file string) bool {
+	if r == nil {
+		return true
+	}
+	for _, deny := range r.Deny {
+		if strings.HasPrefix(file, deny) {
+			return false
+		}
+	}
+	if len(r.Allow) == 0 {
+		return true
+	}
+	for _, allow := range r.Allow {
+		if strings.HasPrefix(file, allow) {
+			return true
+		}
+	}
+	return false
+}
+
+// Config carries the state that can be changed at runtime without
+// restarting the process: a per-logger minimum level table and an optional
+// Trace call-site filter. See ReloadConfig and SetLevel.
+type Config struct {
+	// PerLoggerLevels maps a logger name (as produced by NewNamed and
+	// zap's own dot-joining Named calls, e.g. "http.router") to the
+	// minimum level it should emit. A name with no exact entry falls
+	// back to its closest configured ancestor, walking up the dotted
+	// name ("http.router" -> "http" -> unconfigured).
+	PerLoggerLevels map[string]zapcore.Level
+
+	// TraceFilterRule, if set, restricts which call sites Trace/Tracef
+	// actually emit at, on top of whatever PerLoggerLevels allows.
+	TraceFilterRule *TraceFilterRule
+}
+
+// levelTable is the immutable snapshot swapped in atomically by SetLevel
+// and ReloadConfig. Readers always see a fully-formed table, never a
+// partially-updated map.
+type levelTable struct {
+	levels map[string]zapcore.Level
+	trace  *TraceFilterRule
+}
+
+var currentLevels atomic.Pointer[levelTable]
+
+func init() {
+	currentLevels.Store(&levelTable{levels: map[string]zapcore.Level{}})
+}
+
+// reloadMu serializes SetLevel/ReloadConfig writers; readers never block on
+// it since they only ever see currentLevels.Load().
+var reloadMu sync.Mutex
+
+// SetLevel sets (or replaces) the minimum enabled level for the named
+// logger, taking effect on the very next log call from any process goroutine.
+// It leaves every other configured name untouched.
+func SetLevel(name string, lvl zapcore.Level) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	old := currentLevels.Load()
+	next := &levelTable{
+		levels: make(map[string]zapcore.Level, len(old.levels)+1),
+		trace:  old.trace,
+	}
+	for k, v := range old.levels {
+		next.levels[k] = v
+	}
+	next.levels[name] = lvl
+	currentLevels.Store(next)
+}
+
+// ReloadConfig atomically replaces the whole per-logger level table and the
+// Trace filter rule with cfg. Use this to apply a freshly-read config file
+// in one step, rather than calling SetLevel in a loop.
+func ReloadConfig(cfg Config) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next := &levelTable{
+		levels: make(map[string]zapcore.Level, len(cfg.PerLoggerLevels)),
+		trace:  cfg.TraceFilterRule,
+	}
+	for k, v := range cfg.PerLoggerLevels {
+		next.levels[k] = v
+	}
+	currentLevels.Store(next)
+}
+
+// lookupLevel walks name up its dotted ancestry ("http.router.handler" ->
+// "http.router" -> "http") and returns the first configured level found.
+func lookupLevel(table *levelTable, name string) (zapcore.Level, bool) {
+	for {
+		if lvl, ok := table.levels[name]; ok {
+			return lvl, true
+		}
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			return 0, false
+		}
+		name = name[:idx]
+	}
+}
+
+// dynamicCore wraps a zapcore.Core so the effective level is re-read from
+// currentLevels on every Check instead of being fixed at construction time.
+//
+// Enabled must answer before an Entry (and therefore a logger name) exists,
+// so it always returns true; the real decision happens in Check, once
+// ent.LoggerName is available. fallback is consulted for names that have no
+// entry anywhere in the level table.
+type dynamicCore struct {
+	zapcore.Core
+	fallback zapcore.LevelEnabler
+}
+
+func newDynamicCore(core zapcore.Core, fallback zapcore.LevelEnabler) zapcore.Core {
+	return &dynamicCore{Core: core, fallback: fallback}
+}
+
+func (c *dynamicCore) Enabled(zapcore.Level) bool { return true }
+
+func (c *dynamicCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dynamicCore{Core: c.Core.With(fields), fallback: c.fallback}
+}
+
+func (c *dynamicCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	table := currentLevels.Load()
+	if min, ok := lookupLevel(table, ent.LoggerName); ok {
+		if ent.Level < min {
+			return ce
+		}
+		return c.Core.Check(ent, ce)
+	}
+	if !c.fallback.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// NewNamed builds a production logger whose Core is dynamic-level aware and
+// names it name, so SetLevel(name, ...) and ReloadConfig's PerLoggerLevels
+// take effect for it (and, via dotted-name fallback, for every logger
+// derived from it with further .Named calls).
+//
+// The underlying core is built at TraceLevel - the lowest level this
+// package ever selects - so dynamicCore.Check is the only gate that
+// matters; building it at the production default of Info would mean a
+// per-logger level below Info (e.g. Debug, or TraceLevel itself) could
+// never actually emit, since the inner core would re-reject it against
+// its own fixed Info threshold regardless of what SetLevel/ReloadConfig
+// say. Names with no configured level fall back to Info via a separate,
+// fixed enabler, preserving NewProduction's default verbosity.
+func NewNamed(name string) *zap.Logger {
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(TraceLevel)
+	base := Must(cfg.Build())
+
+	fallback := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	dynamic := base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newDynamicCore(core, fallback)
+	}))
+	return dynamic.Named(name)
+}
+
+// Logger wraps *zap.Logger to add Trace logging at TraceLevel.
+// Trace can't be a method on *zap.Logger itself since that type lives in an
+// upstream package, hence the thin wrapper.
+type Logger struct {
+	*zap.Logger
+}
+
+// WrapLogger adapts an existing *zap.Logger (e.g. one returned by NewNamed)
+// into a Logger so Trace becomes available. Logger has no Tracef; use
+// SugaredLogger for printf-style Trace logging.
+func WrapLogger(l *zap.Logger) *Logger {
+	return &Logger{Logger: l}
+}
+
+// Trace logs msg at TraceLevel if both the logger's effective level and the
+// active TraceFilterRule allow it at the immediate caller's source file.
+func (l *Logger) Trace(msg string, fields ...zap.Field) {
+	if !traceFilterPermits(2) {
+		return
+	}
+	if ce := l.Logger.Check(TraceLevel, msg); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+// SugaredLogger wraps *zap.SugaredLogger to add Trace/Tracef.
+type SugaredLogger struct {
+	*zap.SugaredLogger
+}
+
+// WrapSugared adapts an existing *zap.SugaredLogger into a SugaredLogger so
+// Trace/Tracef become available.
+func WrapSugared(s *zap.SugaredLogger) *SugaredLogger {
+	return &SugaredLogger{SugaredLogger: s}
+}
+
+// Trace logs args at TraceLevel, formatted like fmt.Sprint when args aren't
+// already a single string, subject to the active TraceFilterRule.
+func (s *SugaredLogger) Trace(args ...interface{}) {
+	if !traceFilterPermits(2) {
+		return
+	}
+	s.SugaredLogger.Log(TraceLevel, args...)
+}
+
+// Tracef logs a printf-style message at TraceLevel, subject to the active
+// TraceFilterRule.
+func (s *SugaredLogger) Tracef(template string, args ...interface{}) {
+	if !traceFilterPermits(2) {
+		return
+	}
+	s.SugaredLogger.Logf(TraceLevel, template, args...)
+}
+
+// traceFilterPermits reports whether the active TraceFilterRule allows
+// logging from the caller skip frames above this function.
+func traceFilterPermits(skip int) bool {
+	rule := currentLevels.Load().trace
+	if rule == nil {
+		return true
+	}
+	_, file, _, ok := runtime.Caller(skip)
+	if !ok {
+		return true
+	}
+	return rule.permits(file)
+}
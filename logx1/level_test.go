@@ -0,0 +1,79 @@
+package logx1
+
+import (
+	"testing"
+
+	"github.com/daydev-org/zap"
+	"github.com/daydev-org/zap/zapcore"
+	"github.com/daydev-org/zap/zaptest/observer"
+)
+
+// TestDynamicCoreHonorsLoweredPerLoggerLevel guards against dynamicCore
+// silently dropping entries that SetLevel/ReloadConfig say should pass: the
+// wrapped core must be built at TraceLevel (the lowest level NewNamed ever
+// selects), or a per-logger level below the wrapped core's own fixed
+// threshold would never actually emit, no matter what the level table says.
+func TestDynamicCoreHonorsLoweredPerLoggerLevel(t *testing.T) {
+	defer ReloadConfig(Config{})
+
+	// TraceLevel mirrors the inner core NewNamed builds: the sole gate is
+	// meant to be dynamicCore.Check, not this enabler.
+	obsCore, logs := observer.New(TraceLevel)
+	core := newDynamicCore(obsCore, zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+	SetLevel("http.router", zapcore.DebugLevel)
+
+	tests := []struct {
+		name   string
+		logger string
+		level  zapcore.Level
+		want   bool
+	}{
+		{"configured logger emits at its configured floor", "http.router", zapcore.DebugLevel, true},
+		{"configured logger still drops below its floor", "http.router", TraceLevel, false},
+		{"unconfigured logger falls back to Info and drops Debug", "other.service", zapcore.DebugLevel, false},
+		{"unconfigured logger still passes at Info", "other.service", zapcore.InfoLevel, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.TakeAll()
+			ent := zapcore.Entry{Level: tt.level, LoggerName: tt.logger, Message: "msg"}
+			ce := core.Check(ent, nil)
+			if got := ce != nil; got != tt.want {
+				t.Fatalf("Check(level=%v, logger=%q) enabled = %v, want %v", tt.level, tt.logger, got, tt.want)
+			}
+			if ce != nil {
+				ce.Write()
+				if logs.Len() != 1 {
+					t.Fatalf("expected the entry to reach the wrapped core, got %d observed entries", logs.Len())
+				}
+			}
+		})
+	}
+}
+
+func TestLookupLevelWalksDottedAncestry(t *testing.T) {
+	table := &levelTable{levels: map[string]zapcore.Level{"http": zapcore.WarnLevel}}
+
+	if lvl, ok := lookupLevel(table, "http.router.handler"); !ok || lvl != zapcore.WarnLevel {
+		t.Errorf("lookupLevel(%q) = (%v, %v), want (%v, true)", "http.router.handler", lvl, ok, zapcore.WarnLevel)
+	}
+	if _, ok := lookupLevel(table, "grpc.server"); ok {
+		t.Errorf("lookupLevel(%q) unexpectedly found a configured ancestor", "grpc.server")
+	}
+}
+
+func TestTraceFilterRulePermits(t *testing.T) {
+	rule := &TraceFilterRule{Allow: []string{"/app/internal/"}, Deny: []string{"/app/internal/vendor/"}}
+
+	if !rule.permits("/app/internal/worker/job.go") {
+		t.Error("permits() rejected a file under an allowed prefix")
+	}
+	if rule.permits("/app/internal/vendor/lib.go") {
+		t.Error("permits() accepted a file under a denied prefix, which should always win")
+	}
+	if rule.permits("/app/other/file.go") {
+		t.Error("permits() accepted a file outside every allowed prefix")
+	}
+}
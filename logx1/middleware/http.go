@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/Daydev-Org/utils/logx1"
+	"github.com/daydev-org/zap"
+)
+
+// HTTP wraps next with request-ID propagation, client IP capture, logger
+// injection, panic recovery, and a single access log line emitted once the
+// handler returns. It is the net/http equivalent of Gin.
+func HTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		reqID := r.Header.Get(RequestIDHeader)
+		if reqID == "" {
+			reqID = NewRequestID()
+		}
+
+		ctx := logx1.AttachRequest(r.Context(), reqID, clientIP(r))
+		logger := logx1.FromContext(ctx)
+		w.Header().Set(RequestIDHeader, reqID)
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if rec := recover(); rec != nil {
+				logx1.LogError(logger, panicError(rec), "panic recovered", zap.ByteString("stack", debug.Stack()))
+				if !sw.wroteHeader {
+					sw.WriteHeader(http.StatusInternalServerError)
+				}
+			}
+			logger.Info("http request",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", sw.status),
+				zap.Int("bytes", sw.bytes),
+				zap.Duration("latency", time.Since(start)),
+			)
+		}()
+
+		next.ServeHTTP(sw, r.WithContext(ctx))
+	})
+}
+
+// RecoveryWithLogger returns net/http middleware that recovers panics from
+// next, logs them through logx1.LogError with a stack trace using the
+// logger found in the request's context (see logx1.FromContext), and
+// responds with a bare 500. HTTP already includes this behavior; use
+// RecoveryWithLogger on its own when you have your own access-log
+// middleware and only need panic recovery.
+func RecoveryWithLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger := logx1.FromContext(r.Context())
+				logx1.LogError(logger, panicError(rec), "panic recovered", zap.ByteString("stack", debug.Stack()))
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusWriter records the status code and byte count written through it,
+// since http.ResponseWriter exposes neither after the fact.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
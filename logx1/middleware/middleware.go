@@ -0,0 +1,66 @@
+// Package middleware provides HTTP access-logging and panic-recovery
+// middleware built on top of logx1's context propagation helpers, for both
+// net/http and gin. Both Gin and HTTP generate or propagate a request ID,
+// capture the client IP, attach them to a per-request logger via
+// logx1.AttachRequest, and emit one structured access log line per request.
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader is the header this package reads an incoming request ID
+// from, and writes back onto the response so clients and upstream proxies
+// can correlate logs across services.
+const RequestIDHeader = "X-Request-ID"
+
+// NewRequestID generates a random request ID for requests that don't
+// already carry one via RequestIDHeader.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand.Read practically never fails; fall back to a
+		// fixed marker rather than panicking mid-request.
+		return "unavailable"
+	}
+	return hex.EncodeToString(b)
+}
+
+// clientIP extracts the client address from r, preferring the first entry
+// of X-Forwarded-For, then X-Real-IP, and finally falling back to
+// r.RemoteAddr.
+//
+// Both headers are trusted unconditionally, with no allowlist of trusted
+// proxies - a direct client can set either one to spoof its logged IP.
+// Only rely on this behind a reverse proxy that overwrites or strips these
+// headers before they reach the application.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		if idx := strings.IndexByte(fwd, ','); idx >= 0 {
+			return strings.TrimSpace(fwd[:idx])
+		}
+		return strings.TrimSpace(fwd)
+	}
+	if real := r.Header.Get("X-Real-IP"); real != "" {
+		return strings.TrimSpace(real)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// panicError normalizes a recover() value into an error so it can be
+// passed through logx1.LogError.
+func panicError(rec interface{}) error {
+	if err, ok := rec.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", rec)
+}